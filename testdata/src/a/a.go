@@ -0,0 +1,52 @@
+package a
+
+import "a/sub"
+
+type MyInt int
+type MyFloat float64
+
+const typedInt MyInt = 5 // want `declaring naked literal as value of defined type "a.MyInt"`
+
+func TakesMyInt(x MyInt)        {}
+func TakesVariadic(xs ...MyInt) {}
+func TakesMyFloat(x MyFloat)    {}
+func Generic[T MyInt](x T)      {}
+func Zero[T any]() (z T)        { return }
+
+func useCallArgs() {
+	TakesMyInt(5)       // want `passing naked literal to parameter of defined type "a.MyInt"`
+	TakesVariadic(1, 2) // want `passing naked literal to parameter of defined type "a.MyInt"` `passing naked literal to parameter of defined type "a.MyInt"`
+	Generic[MyInt](3)   // want `passing naked literal to parameter of defined type "a.MyInt"`
+	TakesMyInt(typedInt)
+}
+
+func useLocalConst() {
+	const c = 7
+	TakesMyInt(c) // want `passing naked literal to parameter of defined type "a.MyInt"`
+}
+
+func useImportedConst() {
+	TakesMyFloat(sub.Pi) // want `passing naked literal to parameter of defined type "a.MyFloat"`
+}
+
+func useAssignAndDecl() {
+	var v MyInt
+	v = 5 // want `assigning naked literal to variable of defined type "a.MyInt"`
+	_ = v
+
+	var w MyInt = 5 // want `declaring naked literal as value of defined type "a.MyInt"`
+	_ = w
+
+	x, y := MyInt(0), MyInt(0)
+	x, y = 1, 2 // want `assigning naked literal to variable of defined type "a.MyInt"` `assigning naked literal to variable of defined type "a.MyInt"`
+	_, _ = x, y
+}
+
+func useIndexExpr() {
+	m := map[MyInt]MyInt{}
+	_ = m[1] // want `using naked literal for indexing the value whose key type is defined type "a.MyInt"`
+
+	// Explicit generic instantiation: the index is a type, not a value, and
+	// must not be mistaken for indexing.
+	_ = Zero[MyInt]
+}
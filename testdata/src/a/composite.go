@@ -0,0 +1,45 @@
+package a
+
+type Pair struct {
+	X MyInt
+	Y MyInt
+}
+
+type Config struct {
+	Timeout MyInt
+}
+
+func useSliceComposite() {
+	_ = []MyInt{1, 2} // want `using naked literal as composite literal's element of defined type "a.MyInt"` `using naked literal as composite literal's element of defined type "a.MyInt"`
+}
+
+func useNestedSliceComposite() {
+	_ = [][]MyInt{{3}} // want `using naked literal as composite literal's element of defined type "a.MyInt"`
+}
+
+func useMapComposite() {
+	_ = map[MyInt]MyInt{1: 2} // want `using naked literal as composite literal's element key of defined type "a.MyInt"` `using naked literal as composite literal's element value of defined type "a.MyInt"`
+}
+
+func useStructComposite() {
+	_ = Pair{X: 1, Y: 2} // want `using naked literal as composite literal's element value of defined type "a.MyInt"` `using naked literal as composite literal's element value of defined type "a.MyInt"`
+	_ = Pair{3, 4}       // want `using naked literal as composite literal's element value of defined type "a.MyInt"` `using naked literal as composite literal's element value of defined type "a.MyInt"`
+}
+
+func useElidedStructSliceComposite() {
+	_ = []Pair{{X: 5}} // want `using naked literal as composite literal's element value of defined type "a.MyInt"`
+}
+
+func useStructFieldAssignment() {
+	p := Pair{}
+	p.X = 5 // want `assigning naked literal to variable of defined type "a.MyInt"`
+	_ = p
+}
+
+// usePointerElidedComposite is a regression test for the case where the
+// composite literal's element/field type is a pointer to a defined type
+// (e.g. []*Config), and the spec-sanctioned elided "&Config{...}" form is
+// used for its elements.
+func usePointerElidedComposite() {
+	_ = []*Config{{Timeout: 5}} // want `using naked literal as composite literal's element value of defined type "a.MyInt"`
+}
@@ -0,0 +1,9 @@
+package a
+
+import . "a/sub"
+
+// useDotImportedConst exercises a naked literal resolved through a
+// dot-imported constant (Pi comes from "a/sub" without a package qualifier).
+func useDotImportedConst() {
+	TakesMyFloat(Pi) // want `passing naked literal to parameter of defined type "a.MyFloat"`
+}
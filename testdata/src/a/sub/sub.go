@@ -0,0 +1,5 @@
+package sub
+
+// Pi is an untyped float constant, used to test that naked literals
+// resolved through another package are still recognized as untyped.
+const Pi = 3.14
@@ -0,0 +1,9 @@
+package b
+
+import . "b/other"
+
+func Takes(x Thing) {}
+
+func use() {
+	Takes(5) // want `passing naked literal to parameter of defined type "b/other.Thing"`
+}
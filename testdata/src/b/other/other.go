@@ -0,0 +1,3 @@
+package other
+
+type Thing int
@@ -0,0 +1,9 @@
+package allowed
+
+import "nested/pkg"
+
+func Takes(x pkg.Foo) {}
+
+func use() {
+	Takes(5)
+}
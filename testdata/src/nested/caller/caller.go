@@ -0,0 +1,9 @@
+package caller
+
+import "nested/pkg"
+
+func Takes(x pkg.Foo) {}
+
+func use() {
+	Takes(5) // want `passing naked literal to parameter of defined type "nested/pkg.Foo"`
+}
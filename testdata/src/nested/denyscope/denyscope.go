@@ -0,0 +1,12 @@
+package denyscope
+
+type Foo int
+type Bar int
+
+func TakesFoo(x Foo) {}
+func TakesBar(x Bar) {}
+
+func use() {
+	TakesFoo(5) // want `passing naked literal to parameter of defined type "nested/denyscope.Foo"`
+	TakesBar(5)
+}
@@ -0,0 +1,9 @@
+package strictbool
+
+type Flag bool
+
+func Takes(x Flag) {}
+
+func use() {
+	Takes(true) // want `passing naked literal to parameter of defined type "nested/strictbool.Flag"`
+}
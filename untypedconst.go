@@ -3,8 +3,11 @@ package untypedconst
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"log"
+	"path"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -20,6 +23,24 @@ var Analyzer = &analysis.Analyzer{
 	Requires: []*analysis.Analyzer{inspect.Analyzer},
 }
 
+var (
+	allowFlag       string
+	denyFlag        string
+	ignoreFuncsFlag string
+	strictBoolFlag  bool
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&allowFlag, "allow", "",
+		"comma-separated glob patterns (`pkgpath.Type`, e.g. \"time.Duration\") of defined types to never report")
+	Analyzer.Flags.StringVar(&denyFlag, "deny", "",
+		"comma-separated glob patterns (`pkgpath.Type`) naming the only defined types to report on (a required allow-list, not a block-list, despite the name); if empty, all defined types are eligible")
+	Analyzer.Flags.StringVar(&ignoreFuncsFlag, "ignore-funcs", "",
+		"comma-separated glob patterns (`pkgpath.Func`, e.g. \"fmt.*,log.*\") of functions whose call arguments are never checked")
+	Analyzer.Flags.BoolVar(&strictBoolFlag, "strict-bool", false,
+		"also report naked true/false literals used as values of defined bool types")
+}
+
 func run(pass *analysis.Pass) (interface{}, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	nodeFilter := []ast.Node{
@@ -28,8 +49,16 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		(*ast.SendStmt)(nil),
 		(*ast.CompositeLit)(nil),
 		(*ast.IndexExpr)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
 	}
 
+	// Composite literals are visited independently by Preorder even when
+	// nested, but processCompositeLit recurses into nested literals itself
+	// (carrying the "hint" type inherited from the enclosing literal), so we
+	// track which ones it has already handled to avoid checking them twice.
+	handledCompositeLits := make(map[*ast.CompositeLit]bool)
+
 	inspect.Preorder(nodeFilter, func(node ast.Node) {
 		switch n := node.(type) {
 		case *ast.CallExpr:
@@ -42,10 +71,19 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			processSendStmt(pass, n)
 
 		case *ast.CompositeLit:
-			processCompositeLit(pass, n)
+			if handledCompositeLits[n] {
+				return
+			}
+			processCompositeLit(pass, n, nil, handledCompositeLits)
 
 		case *ast.IndexExpr:
 			processIndexExpr(pass, n)
+
+		case *ast.AssignStmt:
+			processAssignStmt(pass, n)
+
+		case *ast.ValueSpec:
+			processValueSpec(pass, n)
 		}
 	})
 	return nil, nil
@@ -56,11 +94,80 @@ func processCallExpr(pass *analysis.Pass, call *ast.CallExpr) {
 	if fn == nil {
 		return
 	}
-	for _, arg := range call.Args {
-		checkAndReport(pass, arg, "passing naked literal to parameter of defined type %q")
+	sig := calleeSignature(pass, call, fn)
+	calleeName := qualifiedFuncName(fn)
+	for i, arg := range call.Args {
+		checkAndReportForCallee(pass, arg, signatureParamType(sig, i), "passing naked literal to parameter of defined type %q", calleeName)
+	}
+}
+
+func qualifiedFuncName(fn *types.Func) string {
+	if fn.Pkg() == nil {
+		return fn.Name()
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// calleeSignature returns the signature `call` is actually invoking,
+// preferring the instantiated signature recorded for generic callees over
+// the generic function's own (possibly type-parameterized) signature.
+func calleeSignature(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) *types.Signature {
+	if ident := calleeIdent(call.Fun); ident != nil {
+		if inst, ok := pass.TypesInfo.Instances[ident]; ok {
+			if sig, ok := inst.Type.(*types.Signature); ok {
+				return sig
+			}
+		}
+	}
+	sig, _ := fn.Type().(*types.Signature)
+	return sig
+}
+
+// calleeIdent unwraps a call's Fun expression down to the identifier naming
+// the callee, looking through selectors and explicit generic instantiations
+// (CallExpr.Fun is an IndexExpr/IndexListExpr when type args are given
+// explicitly, e.g. `F[MyInt](x)`).
+func calleeIdent(fun ast.Expr) *ast.Ident {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	case *ast.IndexExpr:
+		return calleeIdent(f.X)
+	case *ast.IndexListExpr:
+		return calleeIdent(f.X)
+	default:
+		return nil
 	}
 }
 
+// signatureParamType returns the (possibly instantiated) type of the i-th
+// call argument according to sig, accounting for variadic parameters. It
+// returns nil if sig is nil or i is out of range.
+func signatureParamType(sig *types.Signature, i int) types.Type {
+	if sig == nil {
+		return nil
+	}
+	params := sig.Params()
+	n := params.Len()
+	if n == 0 {
+		return nil
+	}
+	if i < n-1 || !sig.Variadic() {
+		if i >= n {
+			return nil
+		}
+		return params.At(i).Type()
+	}
+	// i-th arg is part of the variadic tail; unwrap the slice type.
+	lastTyp := params.At(n - 1).Type()
+	if slice, ok := lastTyp.(*types.Slice); ok {
+		return slice.Elem()
+	}
+	return lastTyp
+}
+
 func processReturnStmt(pass *analysis.Pass, ret *ast.ReturnStmt) {
 	for _, res := range ret.Results {
 		checkAndReport(pass, res, "returning naked literal as Defiend Type %q")
@@ -71,27 +178,219 @@ func processSendStmt(pass *analysis.Pass, send *ast.SendStmt) {
 	checkAndReport(pass, send.Value, "sending naked literal to channel of Defiend Type %q")
 }
 
-func processCompositeLit(pass *analysis.Pass, comp *ast.CompositeLit) {
+// processCompositeLit checks comp's elements, recursively descending into
+// nested composite literals with an elided type (e.g. the inner `{...}` in
+// `[]MyEnum{{...}}`), carrying the element type that the Go type-checker's
+// own "hint" mechanism would have propagated down to them.
+//
+// `hint` is the expected type for comp itself when comp.Type is nil (elided);
+// it is ignored otherwise. `handled` records every *ast.CompositeLit this
+// function has processed, so the top-level Preorder walk in run() doesn't
+// check nested literals a second time.
+func processCompositeLit(pass *analysis.Pass, comp *ast.CompositeLit, hint types.Type, handled map[*ast.CompositeLit]bool) {
+	handled[comp] = true
+
+	typ := hint
+	if comp.Type != nil {
+		typ = pass.TypesInfo.TypeOf(comp.Type)
+	}
+	if typ == nil {
+		typ = pass.TypesInfo.Types[comp].Type
+	}
+
+	// The spec allows eliding "&" along with the element type in composite
+	// literals of pointer-to-composite element/field type (e.g.
+	// `[]*Foo{{...}}`); unwrap one level of pointer so Foo's shape still
+	// drives the recursion below.
+	if p, ok := underlyingOf(typ).(*types.Pointer); ok {
+		typ = p.Elem()
+	}
+
+	switch u := underlyingOf(typ).(type) {
+	case *types.Slice:
+		processSeqElts(pass, comp, u.Elem(), handled)
+	case *types.Array:
+		processSeqElts(pass, comp, u.Elem(), handled)
+	case *types.Map:
+		processMapElts(pass, comp, u.Key(), u.Elem(), handled)
+	case *types.Struct:
+		processStructElts(pass, comp, u, handled)
+	default:
+		// Expected type couldn't be resolved (or isn't one of the composite
+		// kinds above); fall back to checking each element against its own
+		// inferred type, as before.
+		for _, elt := range comp.Elts {
+			switch e := elt.(type) {
+			case *ast.KeyValueExpr:
+				reportCompositeElt(pass, e.Key, nil, handled, "using naked literal as composite literal's element key of defined type %q")
+				reportCompositeElt(pass, e.Value, nil, handled, "using naked literal as composite literal's element value of defined type %q")
+			default:
+				reportCompositeElt(pass, e, nil, handled, "using naked literal as composite literal's element of defined type %q")
+			}
+		}
+	}
+}
+
+// processSeqElts checks the elements of a slice- or array-typed composite
+// literal against elemTyp.
+func processSeqElts(pass *analysis.Pass, comp *ast.CompositeLit, elemTyp types.Type, handled map[*ast.CompositeLit]bool) {
 	for _, elt := range comp.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			// Indexed element, e.g. `[5]MyInt{2: 10}`; Key is the index, not a value.
+			reportCompositeElt(pass, kv.Value, elemTyp, handled, "using naked literal as composite literal's element of defined type %q")
+			continue
+		}
+		reportCompositeElt(pass, elt, elemTyp, handled, "using naked literal as composite literal's element of defined type %q")
+	}
+}
+
+// processMapElts checks the keys and values of a map-typed composite literal
+// against keyTyp and valTyp respectively.
+func processMapElts(pass *analysis.Pass, comp *ast.CompositeLit, keyTyp, valTyp types.Type, handled map[*ast.CompositeLit]bool) {
+	for _, elt := range comp.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		reportCompositeElt(pass, kv.Key, keyTyp, handled, "using naked literal as composite literal's element key of defined type %q")
+		reportCompositeElt(pass, kv.Value, valTyp, handled, "using naked literal as composite literal's element value of defined type %q")
+	}
+}
+
+// processStructElts checks the fields of a struct-typed composite literal,
+// resolving each field's type by name (keyed form) or position (elided form).
+func processStructElts(pass *analysis.Pass, comp *ast.CompositeLit, st *types.Struct, handled map[*ast.CompositeLit]bool) {
+	for i, elt := range comp.Elts {
 		switch e := elt.(type) {
-		case *ast.KeyValueExpr: // elt is "key: value" form (element of map/struct)
-			checkAndReport(pass, e.Key, "using naked literal as composite literal's element key of defined type %q")
-			checkAndReport(pass, e.Value, "using naked literal as composite literal's element value of defined type %q")
+		case *ast.KeyValueExpr:
+			fieldName, ok := e.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			reportCompositeElt(pass, e.Value, fieldTypeByName(st, fieldName.Name), handled, "using naked literal as composite literal's element value of defined type %q")
+		default:
+			if i >= st.NumFields() {
+				continue
+			}
+			reportCompositeElt(pass, e, st.Field(i).Type(), handled, "using naked literal as composite literal's element value of defined type %q")
+		}
+	}
+}
 
-		default: // elt is not "key: value" form (element of slice/array)
-			checkAndReport(pass, e, "using naked literal as composite literal's element of defined type %q")
+func fieldTypeByName(st *types.Struct, name string) types.Type {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() == name {
+			return st.Field(i).Type()
 		}
 	}
+	return nil
+}
+
+// reportCompositeElt either recurses into expr when it's itself a nested
+// composite literal (passing expectedTyp down as its hint), or checks it as a
+// leaf expression against expectedTyp.
+func reportCompositeElt(pass *analysis.Pass, expr ast.Expr, expectedTyp types.Type, handled map[*ast.CompositeLit]bool, msgfmt string) {
+	if nested, ok := expr.(*ast.CompositeLit); ok {
+		processCompositeLit(pass, nested, expectedTyp, handled)
+		return
+	}
+	checkAndReportAgainst(pass, expr, expectedTyp, msgfmt)
+}
+
+func underlyingOf(typ types.Type) types.Type {
+	if typ == nil {
+		return nil
+	}
+	return typ.Underlying()
 }
 
 func processIndexExpr(pass *analysis.Pass, idx *ast.IndexExpr) {
+	// idx.X may name a generic function or type being explicitly instantiated
+	// (e.g. `F[MyInt]`, `Box[MyInt]`), in which case idx.Index is a type, not
+	// a value, and is of no interest to this analyzer.
+	if isGenericInstantiation(pass, idx.X) {
+		return
+	}
 	checkAndReport(pass, idx.Index, "using naked literal for indexing the value whose key type is defined type %q")
 }
 
+func isGenericInstantiation(pass *analysis.Pass, x ast.Expr) bool {
+	ident := calleeIdent(x)
+	if ident == nil {
+		return false
+	}
+	if _, ok := pass.TypesInfo.Instances[ident]; ok {
+		return true
+	}
+	switch obj := pass.TypesInfo.Uses[ident].(type) {
+	case *types.Func:
+		sig, _ := obj.Type().(*types.Signature)
+		return sig != nil && sig.TypeParams().Len() > 0
+	case *types.TypeName:
+		named, _ := obj.Type().(*types.Named)
+		return named != nil && named.TypeParams().Len() > 0
+	default:
+		return false
+	}
+}
+
+func processAssignStmt(pass *analysis.Pass, assign *ast.AssignStmt) {
+	// Skip the "a, b := f()" form, where a single RHS expands into multiple
+	// LHS values and pairing by index doesn't make sense.
+	if len(assign.Lhs) != len(assign.Rhs) {
+		return
+	}
+	for i, lhs := range assign.Lhs {
+		if isBlankIdent(lhs) {
+			continue
+		}
+		checkAndReport(pass, assign.Rhs[i], "assigning naked literal to variable of defined type %q")
+	}
+}
+
+func processValueSpec(pass *analysis.Pass, spec *ast.ValueSpec) {
+	// e.g. "var a, b = f()": a single value expands into multiple names.
+	if len(spec.Names) != len(spec.Values) {
+		return
+	}
+	for i, name := range spec.Names {
+		if name.Name == "_" {
+			continue
+		}
+		checkAndReport(pass, spec.Values[i], "declaring naked literal as value of defined type %q")
+	}
+}
+
+func isBlankIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
+
 // check if the expression is target of warning, and report problems.
 //
 // `msgfmt` MUST contain exact one format specifier for string(`%s` or `%q`)
 func checkAndReport(pass *analysis.Pass, expr ast.Expr, msgfmt string) {
+	checkAndReportAgainst(pass, expr, nil, msgfmt)
+}
+
+// checkAndReportAgainst is checkAndReport, but lets the caller supply the
+// type `expr` is expected to conform to instead of relying solely on the
+// type the type-checker inferred for `expr` itself. This matters for
+// contexts such as generic instantiations where the inferred type of a
+// naked literal argument may be its default type rather than the type
+// parameter it's bound to.
+//
+// `expectedTyp` may be nil, in which case the inferred type of `expr` is used,
+// matching the behavior of checkAndReport.
+func checkAndReportAgainst(pass *analysis.Pass, expr ast.Expr, expectedTyp types.Type, msgfmt string) {
+	checkAndReportForCallee(pass, expr, expectedTyp, msgfmt, "")
+}
+
+// checkAndReportForCallee is checkAndReportAgainst, additionally taking the
+// fully-qualified name (`pkgpath.Func`) of the function expr is being passed to,
+// so it can be matched against `-ignore-funcs`. Pass "" when expr isn't a
+// call argument.
+func checkAndReportForCallee(pass *analysis.Pass, expr ast.Expr, expectedTyp types.Type, msgfmt string, calleeName string) {
 	// no probrem if expr is not constant expression.
 	if pass.TypesInfo.Types[expr].Value == nil {
 		return
@@ -101,24 +400,113 @@ func checkAndReport(pass *analysis.Pass, expr ast.Expr, msgfmt string) {
 		return
 	}
 
-	inferredType := pass.TypesInfo.Types[expr].Type
+	typ := expectedTyp
+	if typ == nil {
+		typ = pass.TypesInfo.Types[expr].Type
+	}
 
-	namedTyp, isNamed := inferredType.(*types.Named)
+	namedTyp, isNamed := typ.(*types.Named)
 	if !isNamed {
 		return
 	}
-	if _, isUnderlyingBasic := inferredType.Underlying().(*types.Basic); !isUnderlyingBasic {
+	basic, isUnderlyingBasic := typ.Underlying().(*types.Basic)
+	if !isUnderlyingBasic {
+		return
+	}
+	if basic.Info()&types.IsBoolean != 0 && !strictBoolFlag {
 		return
 	}
 
 	// expr is target of warning if the declared type of expr is *not* "external package private type"
-	if namedTyp.Obj().Exported() || namedTyp.Obj().Pkg().Path() == pass.Pkg.Path() {
-		pass.Report(analysis.Diagnostic{
-			Pos:     expr.Pos(),
-			End:     expr.End(),
-			Message: fmt.Sprintf(msgfmt, inferredType.String()),
-		})
+	if !(namedTyp.Obj().Exported() || namedTyp.Obj().Pkg().Path() == pass.Pkg.Path()) {
+		return
+	}
+
+	qualifiedTyp := namedTyp.Obj().Pkg().Path() + "." + namedTyp.Obj().Name()
+	if matchesAnyPattern(allowFlag, qualifiedTyp) {
+		return
+	}
+	// Despite the flag's name, -deny scopes reporting *to* a curated set of
+	// types rather than blocking them: anything that doesn't match is denied,
+	// not anything that does.
+	if denyFlag != "" && !matchesAnyPattern(denyFlag, qualifiedTyp) {
+		return
 	}
+	if calleeName != "" && matchesAnyPattern(ignoreFuncsFlag, calleeName) {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:            expr.Pos(),
+		End:            expr.End(),
+		Message:        fmt.Sprintf(msgfmt, typ.String()),
+		SuggestedFixes: []analysis.SuggestedFix{conversionFix(pass, expr, namedTyp)},
+	})
+}
+
+// matchesAnyPattern reports whether name matches any of the comma-separated
+// glob patterns in patterns (as interpreted by path.Match).
+func matchesAnyPattern(patterns string, name string) bool {
+	if patterns == "" {
+		return false
+	}
+	for _, pat := range strings.Split(patterns, ",") {
+		if ok, _ := path.Match(strings.TrimSpace(pat), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// conversionFix builds the SuggestedFix that wraps `expr` in a conversion to
+// `namedTyp`, e.g. rewriting `5` into `MyInt(5)`.
+func conversionFix(pass *analysis.Pass, expr ast.Expr, namedTyp *types.Named) analysis.SuggestedFix {
+	typeName := qualifiedTypeName(pass, expr.Pos(), namedTyp)
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("convert to %s", typeName),
+		TextEdits: []analysis.TextEdit{
+			{Pos: expr.Pos(), End: expr.Pos(), NewText: []byte(typeName + "(")},
+			{Pos: expr.End(), End: expr.End(), NewText: []byte(")")},
+		},
+	}
+}
+
+// qualifiedTypeName returns how `namedTyp` should be spelled out at `pos`,
+// reusing whatever import alias the enclosing file already uses for its
+// package.
+func qualifiedTypeName(pass *analysis.Pass, pos token.Pos, namedTyp *types.Named) string {
+	obj := namedTyp.Obj()
+	pkg := obj.Pkg()
+	if pkg == nil || pkg == pass.Pkg {
+		return obj.Name()
+	}
+
+	if file := enclosingFile(pass, pos); file != nil {
+		for _, imp := range file.Imports {
+			impPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil || impPath != pkg.Path() {
+				continue
+			}
+			if imp.Name != nil && imp.Name.Name != "_" {
+				if imp.Name.Name == "." {
+					// Dot-imported: namedTyp's name is already in scope unqualified.
+					return obj.Name()
+				}
+				return imp.Name.Name + "." + obj.Name()
+			}
+			break
+		}
+	}
+	return pkg.Name() + "." + obj.Name()
+}
+
+func enclosingFile(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.Pos() <= pos && pos < f.End() {
+			return f
+		}
+	}
+	return nil
 }
 
 // check if `expr` is untyped.
@@ -137,13 +525,11 @@ func isUntypedConstExpr(pass *analysis.Pass, expr ast.Expr) bool {
 		if _, isConst := constIdentNames[e.Name]; isConst {
 			return true
 		}
-		// Lookup `types.Object`(type information about entity of code) associated with the ident and check its type.
-		cnst, ok := pass.Pkg.Scope().Lookup(e.Name).(*types.Const)
-		if !ok {
-			// should be unreachable
-			return false
-		}
-		return strings.HasPrefix(cnst.Type().String(), "untyped")
+		return isUntypedConstObj(pass.TypesInfo.ObjectOf(e))
+
+	case *ast.SelectorExpr:
+		// `pkg.Const` form, referring to a constant declared in another package.
+		return isUntypedConstObj(pass.TypesInfo.ObjectOf(e.Sel))
 
 	case *ast.UnaryExpr:
 		// If an operand is untyped, entire expression is also untyped.
@@ -180,12 +566,25 @@ func isUntypedConstExpr(pass *analysis.Pass, expr ast.Expr) bool {
 		return true
 
 	default:
-		// All other types of expression (index, key-value, selector, slice, star) can't appear in const expr.
+		// All other types of expression (index, key-value, slice, star) can't appear in const expr.
 		log.Printf("unexpected node type: %T", e)
 		return false
 	}
 }
 
+// isUntypedConstObj reports whether obj is a constant declared without an
+// explicit type (e.g. `const c = 5`, as opposed to `const c MyInt = 5`).
+func isUntypedConstObj(obj types.Object) bool {
+	cnst, ok := obj.(*types.Const)
+	if !ok {
+		// should be unreachable: callers only pass idents resolved from
+		// expressions already known to be constant expressions.
+		return false
+	}
+	basic, ok := cnst.Type().(*types.Basic)
+	return ok && basic.Info()&types.IsUntyped != 0
+}
+
 func unwrapParens(expr ast.Expr) ast.Expr {
 	currExpr := expr
 	for {
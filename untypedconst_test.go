@@ -0,0 +1,77 @@
+package untypedconst
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer exercises the analyzer's default behavior (no flags set)
+// across call args, assignments, var/const decls, generics, untyped
+// consts (local/imported/dot-imported), index exprs, and composite
+// literals (incl. nested/elided and pointer-elided ones).
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+// TestSuggestedFix verifies that the SuggestedFix wraps naked literals with
+// a conversion that is itself valid Go, including when the defined type
+// comes from a dot-imported package (where a qualified "pkg.Type(" prefix
+// would be invalid).
+func TestSuggestedFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "b")
+}
+
+// TestAllowFlag verifies that -allow matches by full package import path,
+// not by the package's short (possibly ambiguous) name.
+func TestAllowFlag(t *testing.T) {
+	// A pattern built from the package's short name ("pkg") must NOT
+	// suppress a diagnostic for a type whose import path is "nested/pkg":
+	// the diagnostic should still fire.
+	setFlag(t, &allowFlag, "pkg.Foo")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nested/caller")
+}
+
+// TestAllowFlagFullPath verifies that -allow does suppress a diagnostic
+// when given the type's full package import path.
+func TestAllowFlagFullPath(t *testing.T) {
+	setFlag(t, &allowFlag, "nested/pkg.Foo")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nested/allowed")
+}
+
+// TestIgnoreFuncsFlag verifies that -ignore-funcs also matches by the
+// callee's full package import path.
+func TestIgnoreFuncsFlag(t *testing.T) {
+	setFlag(t, &ignoreFuncsFlag, "nested/ignored.Takes")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nested/ignored")
+}
+
+// TestDenyFlag verifies that -deny restricts reporting to defined types
+// matching one of its patterns, suppressing diagnostics for every other
+// defined type.
+func TestDenyFlag(t *testing.T) {
+	setFlag(t, &denyFlag, "nested/denyscope.Foo")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nested/denyscope")
+}
+
+// TestStrictBoolFlag verifies that -strict-bool additionally reports naked
+// true/false literals used as values of defined bool types, which are
+// otherwise hard-coded as allowed.
+func TestStrictBoolFlag(t *testing.T) {
+	setBoolFlag(t, &strictBoolFlag, true)
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nested/strictbool")
+}
+
+func setFlag(t *testing.T, flag *string, value string) {
+	t.Helper()
+	prev := *flag
+	*flag = value
+	t.Cleanup(func() { *flag = prev })
+}
+
+func setBoolFlag(t *testing.T, flag *bool, value bool) {
+	t.Helper()
+	prev := *flag
+	*flag = value
+	t.Cleanup(func() { *flag = prev })
+}